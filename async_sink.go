@@ -0,0 +1,184 @@
+package colored_logging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy controls what AsyncSink does with a record when its
+// buffer channel is full.
+type OverflowPolicy int
+
+const (
+	// Block makes Write wait for room in the buffer, same as writing to
+	// the underlying sink directly would if it were slow.
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest buffered record to make room for
+	// the new one.
+	DropOldest
+	// DropNewest discards the record being written, leaving the buffer
+	// untouched.
+	DropNewest
+)
+
+// ErrAsyncSinkClosed is returned by Write and Flush once Close has been
+// called.
+var ErrAsyncSinkClosed = errors.New("colored_logging: async sink is closed")
+
+// AsyncSinkOptions configures an AsyncSink.
+type AsyncSinkOptions struct {
+	// Capacity is the number of records the buffer channel holds before
+	// Overflow kicks in. Values <= 0 are treated as 1.
+	Capacity int
+	// Overflow is the policy applied once Capacity is reached.
+	Overflow OverflowPolicy
+}
+
+// asyncItem is either a buffered record (data != nil) or a flush marker
+// (flush != nil), carried on the same channel so Flush observes the
+// records queued ahead of it without a second synchronization path.
+type asyncItem struct {
+	data  []byte
+	flush chan struct{}
+}
+
+// AsyncSink wraps another Sink so that Write buffers the formatted
+// record into a bounded channel and returns immediately; a single
+// background goroutine drains the channel to the wrapped sink in order,
+// so Fatal/Error calls in latency-sensitive request handlers don't block
+// on a slow disk or network write. Because the wrapped sink is only
+// ever touched from the drain goroutine, wrapping a FileSink means its
+// rotation work also happens there, off the caller's hot path.
+type AsyncSink struct {
+	next   Sink
+	policy OverflowPolicy
+
+	// mu coordinates Write/Flush (which send on queue under RLock)
+	// against Close (which closes queue under Lock), so queue is never
+	// sent on after it's closed.
+	mu     sync.RWMutex
+	closed bool
+
+	queue   chan asyncItem
+	dropped atomic.Int64
+	done    chan struct{} // closed once the drain goroutine returns
+}
+
+// NewAsyncSink starts a background goroutine draining into next and
+// returns the AsyncSink wrapping it. Close stops the goroutine and
+// closes next in turn.
+func NewAsyncSink(next Sink, opts AsyncSinkOptions) *AsyncSink {
+	capacity := opts.Capacity
+	if capacity <= 0 {
+		capacity = 1
+	}
+	a := &AsyncSink{
+		next:   next,
+		policy: opts.Overflow,
+		queue:  make(chan asyncItem, capacity),
+		done:   make(chan struct{}),
+	}
+	go a.drain()
+	return a
+}
+
+// drain is the single goroutine that ever touches next, preserving
+// per-sink ordering and letting next's own rotation/locking stay simple.
+func (a *AsyncSink) drain() {
+	defer close(a.done)
+	for item := range a.queue {
+		if item.flush != nil {
+			close(item.flush)
+			continue
+		}
+		a.next.Write(item.data)
+	}
+}
+
+// Write implements Sink. p is copied before queuing since callers (the
+// Logger's pooled buffer) reuse it the instant Write returns.
+func (a *AsyncSink) Write(p []byte) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return 0, ErrAsyncSinkClosed
+	}
+	a.enqueue(asyncItem{data: append([]byte(nil), p...)})
+	return len(p), nil
+}
+
+// enqueue applies the configured OverflowPolicy. Called with a.mu held
+// for reading, so it never races a concurrent Close.
+func (a *AsyncSink) enqueue(item asyncItem) {
+	switch a.policy {
+	case DropNewest:
+		select {
+		case a.queue <- item:
+		default:
+			a.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case a.queue <- item:
+				return
+			default:
+			}
+			select {
+			case <-a.queue:
+				a.dropped.Add(1)
+			default:
+			}
+		}
+	default: // Block
+		a.queue <- item
+	}
+}
+
+// DroppedCount returns the number of records discarded so far under
+// DropOldest/DropNewest.
+func (a *AsyncSink) DroppedCount() int64 {
+	return a.dropped.Load()
+}
+
+// Flush implements Flusher: it blocks until every record queued ahead
+// of the call has reached next, or ctx is done first.
+func (a *AsyncSink) Flush(ctx context.Context) error {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return ErrAsyncSinkClosed
+	}
+	marker := asyncItem{flush: make(chan struct{})}
+	select {
+	case a.queue <- marker:
+		a.mu.RUnlock()
+	case <-ctx.Done():
+		a.mu.RUnlock()
+		return ctx.Err()
+	}
+	select {
+	case <-marker.flush:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new records, drains whatever is already queued
+// to next, and closes next. It is safe to call more than once.
+func (a *AsyncSink) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+	return a.next.Close()
+}
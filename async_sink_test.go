@@ -0,0 +1,112 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingSink blocks every Write until release is closed, so tests can
+// force AsyncSink's buffer to fill.
+type blockingSink struct {
+	mu      sync.Mutex
+	release chan struct{}
+	written [][]byte
+}
+
+func (s *blockingSink) Write(p []byte) (int, error) {
+	<-s.release
+	s.mu.Lock()
+	s.written = append(s.written, append([]byte(nil), p...))
+	s.mu.Unlock()
+	return len(p), nil
+}
+
+func (s *blockingSink) Close() error { return nil }
+
+func (s *blockingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.written)
+}
+
+func TestAsyncSinkDropNewestOnOverflow(t *testing.T) {
+	next := &blockingSink{release: make(chan struct{})}
+	sink := log.NewAsyncSink(next, log.AsyncSinkOptions{Capacity: 1, Overflow: log.DropNewest})
+
+	// The first write is picked up by the drain goroutine and blocks on
+	// next, so the buffer channel itself stays empty until it returns.
+	sink.Write([]byte("first\n"))
+	time.Sleep(10 * time.Millisecond)
+	sink.Write([]byte("second\n")) // fills the 1-slot buffer
+	sink.Write([]byte("third\n"))  // should be dropped
+
+	if got := sink.DroppedCount(); got != 1 {
+		t.Fatalf("expected 1 dropped record, got %d", got)
+	}
+
+	close(next.release)
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := next.count(); got != 2 {
+		t.Fatalf("expected 2 records to reach next, got %d", got)
+	}
+}
+
+func TestAsyncSinkFlushWaitsForQueuedRecords(t *testing.T) {
+	next := &blockingSink{release: make(chan struct{})}
+	close(next.release) // never actually blocks in this test
+	sink := log.NewAsyncSink(next, log.AsyncSinkOptions{Capacity: 8})
+
+	for i := 0; i < 5; i++ {
+		sink.Write([]byte("line\n"))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := next.count(); got != 5 {
+		t.Fatalf("expected all 5 records flushed to next, got %d", got)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestAsyncSinkWriteAfterCloseErrors(t *testing.T) {
+	next := &blockingSink{release: make(chan struct{})}
+	close(next.release)
+	sink := log.NewAsyncSink(next, log.AsyncSinkOptions{Capacity: 1})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := sink.Write([]byte("late\n")); err != log.ErrAsyncSinkClosed {
+		t.Fatalf("expected ErrAsyncSinkClosed, got %v", err)
+	}
+}
+
+func TestLoggerAddSinkWithAsyncSink(t *testing.T) {
+	next := &blockingSink{release: make(chan struct{})}
+	close(next.release)
+	async := log.NewAsyncSink(next, log.AsyncSinkOptions{Capacity: 8})
+
+	logger := log.New(devNull{}).WithoutColor()
+	logger.AddSink(async)
+	logger.Info("async fan-out")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := async.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := next.count(); got != 1 {
+		t.Fatalf("expected 1 record to reach next, got %d", got)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -1,29 +1,35 @@
 package colored_logging
 
-// Buffer type wrap up byte slice built-in type
-type Buffer []byte
+// ColorBuffer is the byte-slice buffer Formatters render an Entry into.
+// TextFormatter writes building blocks (raw bytes, integers, ANSI color
+// codes) straight into Buffer rather than through fmt; JSONFormatter and
+// LogfmtFormatter use encoding/json and fmt for parts of a line. Buffers
+// are drawn from a pool and reused (see Logger.getBuffer/putBuffer).
+type ColorBuffer struct {
+	Buffer []byte
+}
 
 // Reset buffer position to start
-func (b *Buffer) Reset() {
-	*b = Buffer([]byte(*b)[:0])
+func (b *ColorBuffer) Reset() {
+	b.Buffer = b.Buffer[:0]
 }
 
 // Append byte slice to buffer
-func (b *Buffer) Append(data []byte) {
-	*b = append(*b, data...)
+func (b *ColorBuffer) Append(data []byte) {
+	b.Buffer = append(b.Buffer, data...)
 }
 
 // AppendByte to buffer
-func (b *Buffer) AppendByte(data byte) {
-	*b = append(*b, data)
+func (b *ColorBuffer) AppendByte(data byte) {
+	b.Buffer = append(b.Buffer, data)
 }
 
 // AppendInt to buffer
-func (b *Buffer) AppendInt(remaining int, width int) {
+func (b *ColorBuffer) AppendInt(remaining int, width int) {
 	var repr [8]byte
 	reprCount := len(repr) - 1
 	for remaining >= 10 || width > 1 {
-		reminder := val / 10
+		reminder := remaining / 10
 		repr[reprCount] = byte('0' + remaining - reminder*10)
 		remaining = reminder
 		reprCount--
@@ -34,6 +40,6 @@ func (b *Buffer) AppendInt(remaining int, width int) {
 }
 
 // Bytes return underlying slice data
-func (b Buffer) Bytes() []byte {
-	return []byte(b)
+func (b *ColorBuffer) Bytes() []byte {
+	return b.Buffer
 }
@@ -0,0 +1,95 @@
+package colored_logging
+
+// ANSI escape codes used to color terminal output. off ends whichever
+// color was last turned on.
+const (
+	ansiRed    = "\x1b[31m"
+	ansiBlue   = "\x1b[34m"
+	ansiOrange = "\x1b[33m"
+	ansiGreen  = "\x1b[32m"
+	ansiPurple = "\x1b[35m"
+	ansiCyan   = "\x1b[36m"
+	ansiGray   = "\x1b[90m"
+	ansiOff    = "\x1b[0m"
+)
+
+// Blue appends the ANSI escape for blue, used by TextFormatter to color
+// the timestamp.
+func (b *ColorBuffer) Blue() {
+	b.Append([]byte(ansiBlue))
+}
+
+// Orange appends the ANSI escape for orange, used by TextFormatter to
+// color caller location. WarnPrefix is colored with the Orange function.
+func (b *ColorBuffer) Orange() {
+	b.Append([]byte(ansiOrange))
+}
+
+// Green appends the ANSI escape for green. InfoPrefix is colored with
+// the Green function.
+func (b *ColorBuffer) Green() {
+	b.Append([]byte(ansiGreen))
+}
+
+// Purple appends the ANSI escape for purple. DebugPrefix is colored with
+// the Purple function.
+func (b *ColorBuffer) Purple() {
+	b.Append([]byte(ansiPurple))
+}
+
+// Cyan appends the ANSI escape for cyan, used by TextFormatter to color
+// attribute keys. TracePrefix is colored with the Cyan function.
+func (b *ColorBuffer) Cyan() {
+	b.Append([]byte(ansiCyan))
+}
+
+// Gray appends the ANSI escape for gray, used by TextFormatter to color
+// call stack frames.
+func (b *ColorBuffer) Gray() {
+	b.Append([]byte(ansiGray))
+}
+
+// Off appends the ANSI reset escape.
+func (b *ColorBuffer) Off() {
+	b.Append([]byte(ansiOff))
+}
+
+// Red wraps data in the ANSI escapes for red, returning a new byte
+// slice. Used to precompute the colored form of a Prefix (see
+// FatalPrefix/ErrorPrefix in log.go) once, rather than on every call.
+func Red(data []byte) []byte {
+	return wrapColor(ansiRed, data)
+}
+
+// Orange wraps data in the ANSI escapes for orange, returning a new
+// byte slice.
+func Orange(data []byte) []byte {
+	return wrapColor(ansiOrange, data)
+}
+
+// Green wraps data in the ANSI escapes for green, returning a new byte
+// slice.
+func Green(data []byte) []byte {
+	return wrapColor(ansiGreen, data)
+}
+
+// Purple wraps data in the ANSI escapes for purple, returning a new byte
+// slice.
+func Purple(data []byte) []byte {
+	return wrapColor(ansiPurple, data)
+}
+
+// Cyan wraps data in the ANSI escapes for cyan, returning a new byte
+// slice.
+func Cyan(data []byte) []byte {
+	return wrapColor(ansiCyan, data)
+}
+
+// wrapColor returns data wrapped between code and the reset escape.
+func wrapColor(code string, data []byte) []byte {
+	wrapped := make([]byte, 0, len(code)+len(data)+len(ansiOff))
+	wrapped = append(wrapped, code...)
+	wrapped = append(wrapped, data...)
+	wrapped = append(wrapped, ansiOff...)
+	return wrapped
+}
@@ -0,0 +1,206 @@
+package colored_logging
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileSinkOptions configures the rotation and retention policy a
+// FileSink enforces. The zero value disables rotation entirely: the
+// sink just appends to its path forever, matching the old WithLogFile
+// behavior.
+type FileSinkOptions struct {
+	// MaxSizeBytes rotates the active file once writing to it would
+	// exceed this size. Zero disables size-based rotation.
+	MaxSizeBytes int64
+
+	// RotateDaily/RotateHourly rotate the active file at the next UTC
+	// day/hour boundary after it was opened. At most one should be set;
+	// RotateDaily wins if both are.
+	RotateDaily  bool
+	RotateHourly bool
+
+	// MaxBackups caps the number of rotated segments kept alongside the
+	// active file; the oldest are removed first. Zero means unlimited.
+	MaxBackups int
+
+	// MaxAgeDays removes rotated segments older than this many days.
+	// Zero disables age-based cleanup.
+	MaxAgeDays int
+
+	// Compress gzips each rotated segment once it's closed out.
+	Compress bool
+}
+
+// FileSink is a Sink that writes to a path on disk, rotating it per
+// Options and pruning old segments per MaxBackups/MaxAgeDays.
+type FileSink struct {
+	path string
+	opts FileSinkOptions
+
+	mu         sync.Mutex
+	file       *os.File
+	size       int64
+	nextRotate time.Time // zero if time-based rotation is off
+}
+
+// NewFileSink opens path for appending, creating it if needed, and
+// returns a FileSink enforcing opts.
+func NewFileSink(path string, opts FileSinkOptions) (*FileSink, error) {
+	s := &FileSink{path: path, opts: opts}
+	if err := s.openCurrentLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// openCurrentLocked (re)opens s.path for appending and resets size and
+// the next rotation deadline from the file's current state.
+func (s *FileSink) openCurrentLocked() error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	s.nextRotate = s.nextRotateDeadline(time.Now())
+	return nil
+}
+
+// nextRotateDeadline returns the next UTC day/hour boundary after from,
+// per the configured time-based policy, or the zero Time if neither
+// RotateDaily nor RotateHourly is set.
+func (s *FileSink) nextRotateDeadline(from time.Time) time.Time {
+	y, m, d := from.UTC().Date()
+	switch {
+	case s.opts.RotateDaily:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	case s.opts.RotateHourly:
+		return time.Date(y, m, d, from.UTC().Hour(), 0, 0, 0, time.UTC).Add(time.Hour)
+	default:
+		return time.Time{}
+	}
+}
+
+// Write implements Sink, rotating first if size or time policy demands
+// it.
+func (s *FileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shouldRotateLocked(len(p)) {
+		if err := s.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := s.file.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+func (s *FileSink) shouldRotateLocked(nextWrite int) bool {
+	if s.opts.MaxSizeBytes > 0 && s.size+int64(nextWrite) > s.opts.MaxSizeBytes {
+		return true
+	}
+	return !s.nextRotate.IsZero() && !time.Now().Before(s.nextRotate)
+}
+
+// rotateLocked closes the active file, renames it aside with a
+// timestamp suffix (compressing it if Compress is set), reopens path
+// fresh, and prunes old segments per MaxBackups/MaxAgeDays.
+func (s *FileSink) rotateLocked() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	rotated := s.path + "." + time.Now().UTC().Format("20060102T150405.000000000")
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+	if s.opts.Compress {
+		if err := compressFile(rotated); err != nil {
+			return err
+		}
+	}
+	if err := s.openCurrentLocked(); err != nil {
+		return err
+	}
+	return s.pruneLocked()
+}
+
+// pruneLocked removes rotated segments beyond MaxBackups or older than
+// MaxAgeDays. Segment names sort chronologically because rotateLocked
+// suffixes them with a fixed-width timestamp.
+func (s *FileSink) pruneLocked() error {
+	if s.opts.MaxBackups <= 0 && s.opts.MaxAgeDays <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+	sort.Strings(matches)
+
+	if s.opts.MaxAgeDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(s.opts.MaxAgeDays) * 24 * time.Hour)
+		kept := matches[:0]
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.ModTime().Before(cutoff) {
+				os.Remove(m)
+				continue
+			}
+			kept = append(kept, m)
+		}
+		matches = kept
+	}
+	if s.opts.MaxBackups > 0 && len(matches) > s.opts.MaxBackups {
+		for _, m := range matches[:len(matches)-s.opts.MaxBackups] {
+			os.Remove(m)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// compressFile gzips path in place as path+".gz" and removes the
+// uncompressed original.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
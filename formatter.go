@@ -0,0 +1,55 @@
+package colored_logging
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Entry captures everything needed to render one log line: the message,
+// its level/prefix, caller info, structured attributes collected via the
+// slog integration, and an optional call stack (used by TracePrefix).
+type Entry struct {
+	Level    slog.Level
+	Prefix   Prefix
+	Time     time.Time
+	File     string
+	Function string
+	Line     int
+	Message  string
+	Attrs    []slog.Attr
+	Stack    []StackFrame
+
+	// Colored and ShowTimestamp mirror the logger's color/timestamp
+	// settings at the time the entry was built, so a Formatter doesn't
+	// need a back-reference to the Logger.
+	Colored       bool
+	ShowTimestamp bool
+}
+
+// StackFrame is one entry of Entry.Stack.
+type StackFrame struct {
+	File     string
+	Function string
+	Line     int
+}
+
+// Formatter renders an Entry into buf. Implementations must not retain
+// entry or buf beyond the call; buf is returned to a pool and reused for
+// later lines.
+type Formatter interface {
+	Format(entry *Entry, buf *ColorBuffer) error
+}
+
+// timeFormat is the timestamp layout shared by the structured formatters.
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// trimTrailingNewline strips the newline fmt.Sprintln appends in Info,
+// Error and the other non-f logging methods, so formatters
+// that encode the message as a delimited field (JSON, logfmt) don't end
+// up with an embedded "\n" inside the value.
+func trimTrailingNewline(msg string) string {
+	if n := len(msg); n > 0 && msg[n-1] == '\n' {
+		return msg[:n-1]
+	}
+	return msg
+}
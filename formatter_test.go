@@ -0,0 +1,45 @@
+package colored_logging_test
+
+import (
+	"bytes"
+	log "colored_logging"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatters(t *testing.T) {
+	log.New(os.Stdout).WithFormatter(log.JSONFormatter{}).Info("json line")
+	log.New(os.Stdout).WithFormatter(log.LogfmtFormatter{}).Info("logfmt line")
+}
+
+func TestJSONFormatterOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(fdBuffer{&buf}).WithFormatter(log.JSONFormatter{}).WithoutTimestamp()
+	logger.Info("hello")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &decoded); err != nil {
+		t.Fatalf("expected valid JSON line, got %q: %v", buf.String(), err)
+	}
+	if decoded["msg"] != "hello" {
+		t.Fatalf("expected msg=hello, got %v", decoded["msg"])
+	}
+}
+
+func TestLogfmtFormatterOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(fdBuffer{&buf}).WithFormatter(log.LogfmtFormatter{})
+	logger.Info("hello")
+
+	if !strings.Contains(buf.String(), "msg=hello") {
+		t.Fatalf("expected logfmt output to contain msg=hello, got %q", buf.String())
+	}
+}
+
+type fdBuffer struct {
+	*bytes.Buffer
+}
+
+func (fdBuffer) Fd() uintptr { return uintptr(0) }
@@ -0,0 +1,50 @@
+package colored_logging
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// JSONFormatter renders an Entry as a single JSON object per line,
+// suitable for log shippers that expect structured input.
+type JSONFormatter struct{}
+
+type jsonEntry struct {
+	Time    string         `json:"time"`
+	Level   string         `json:"level"`
+	File    string         `json:"file,omitempty"`
+	Line    int            `json:"line,omitempty"`
+	Func    string         `json:"func,omitempty"`
+	Message string         `json:"msg"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(entry *Entry, buf *ColorBuffer) error {
+	data, err := json.Marshal(jsonEntry{
+		Time:    entry.Time.Format(timeFormat),
+		Level:   levelName(entry.Level),
+		File:    entry.File,
+		Line:    entry.Line,
+		Func:    entry.Function,
+		Message: trimTrailingNewline(entry.Message),
+		Attrs:   attrsToMap(entry.Attrs),
+	})
+	if err != nil {
+		return err
+	}
+	buf.Append(data)
+	buf.AppendByte('\n')
+	return nil
+}
+
+func attrsToMap(attrs []slog.Attr) map[string]any {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Value.Any()
+	}
+	return m
+}
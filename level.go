@@ -0,0 +1,72 @@
+package colored_logging
+
+import "log/slog"
+
+// Level is the logging severity threshold a Logger filters against. It
+// reuses slog.Level's granularity and ordering (lower is more verbose)
+// so Logger's own API and its slog.Handler integration always agree on
+// what a given level means.
+type Level = slog.Level
+
+// The Level values a Logger recognizes, from most to least verbose.
+// LevelDebug, LevelInfo, LevelWarn and LevelError mirror the slog
+// constants of the same name; LevelTrace and LevelFatal extend the scale
+// to match this package's Trace and Fatal prefixes.
+const (
+	LevelTrace = slog.Level(-8)
+	LevelDebug = slog.LevelDebug
+	LevelInfo  = slog.LevelInfo
+	LevelWarn  = slog.LevelWarn
+	LevelError = slog.LevelError
+	LevelFatal = slog.Level(12)
+)
+
+// Level returns l's current severity threshold. Info, Warn, Debug and
+// the other severity methods drop calls below it before an Entry is even
+// built; Output itself does not check it.
+func (l *Logger) Level() Level {
+	return Level(l.level.Load())
+}
+
+// SetLevel sets l's severity threshold in place.
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// WithLevel is the chainable form of SetLevel.
+func (l *Logger) WithLevel(level Level) *Logger {
+	l.SetLevel(level)
+	return l
+}
+
+// levelName returns level's display name. slog.Level.String() has no
+// knowledge of LevelTrace/LevelFatal and renders them as "DEBUG-4"/
+// "ERROR+4"; formatters that print a level string (JSONFormatter,
+// LogfmtFormatter) should use this instead, mirroring prefixForLevel's
+// boundaries but keeping Fatal distinct from Error.
+func levelName(level Level) string {
+	switch {
+	case level >= LevelFatal:
+		return "FATAL"
+	case level >= LevelError:
+		return "ERROR"
+	case level >= LevelWarn:
+		return "WARN"
+	case level >= LevelInfo:
+		return "INFO"
+	case level >= LevelDebug:
+		return "DEBUG"
+	default:
+		return "TRACE"
+	}
+}
+
+// enabled reports whether level should be emitted: l isn't quiet and
+// level meets l's current threshold. Every severity-specific method
+// (Info, Warn, ...) and the slog Enabled handler route through this.
+func (l *Logger) enabled(level Level) bool {
+	if l.IsQuiet() {
+		return false
+	}
+	return level >= l.Level()
+}
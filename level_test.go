@@ -0,0 +1,49 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"os"
+	"testing"
+)
+
+func TestLevelFiltersOutput(t *testing.T) {
+	logger := log.New(os.Stdout).WithLevel(log.LevelWarn)
+	if logger.IsDebug() {
+		t.Fatal("logger at LevelWarn should not report IsDebug")
+	}
+	logger.Info("should be filtered out")
+	logger.Warn("should pass")
+	logger.Error("should pass")
+}
+
+func TestWithDebugSetsLevelDebug(t *testing.T) {
+	logger := log.New(os.Stdout).WithDebug()
+	if logger.Level() != log.LevelDebug {
+		t.Fatalf("expected LevelDebug, got %v", logger.Level())
+	}
+	logger.WithoutDebug()
+	if logger.Level() != log.LevelInfo {
+		t.Fatalf("expected LevelInfo after WithoutDebug, got %v", logger.Level())
+	}
+}
+
+func TestVGatesOnGlobalVerbosity(t *testing.T) {
+	logger := log.New(os.Stdout).SetVerbosity(1)
+	if logger.V(2) == logger {
+		t.Fatal("V(2) should return the no-op logger when verbosity is 1")
+	}
+	if logger.V(1) != logger {
+		t.Fatal("V(1) should return logger itself when verbosity is 1")
+	}
+	logger.V(2).Info("should be dropped silently")
+}
+
+func TestSetVModuleOverridesGlobalVerbosity(t *testing.T) {
+	logger := log.New(os.Stdout).SetVerbosity(0).SetVModule("level_test=3")
+	if logger.V(3) != logger {
+		t.Fatal("V(3) should be enabled via the level_test=3 vmodule override")
+	}
+	if logger.V(4) == logger {
+		t.Fatal("V(4) should still be disabled above the vmodule override")
+	}
+}
@@ -3,10 +3,12 @@ package colored_logging
 import (
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/term"
@@ -17,16 +19,42 @@ type FdWriter interface {
 	Fd() uintptr
 }
 
+// maxPooledBufferSize caps the capacity of a ColorBuffer returned to
+// bufPool so one outsized log line doesn't pin a huge allocation in the
+// pool forever.
+const maxPooledBufferSize = 64 * 1024
+
 type Logger struct {
-	depth     int
-	mu        sync.RWMutex
-	color     bool
-	out       FdWriter
-	debug     bool
-	timestamp bool
-	quiet     bool
-	logFile   *os.File
-	buf       ColorBuffer
+	// color, level, verbosity, timestamp, quiet and depth are read on
+	// every log call and only occasionally written, so they're atomics
+	// rather than fields behind the write mutex below.
+	color     atomic.Bool
+	level     atomic.Int32
+	verbosity atomic.Int32
+	vmodule   atomic.Pointer[vmoduleTable]
+	timestamp atomic.Bool
+	quiet     atomic.Bool
+	depth     atomic.Int32
+
+	out FdWriter
+
+	// writeMu is only held around the final write to out (or sinks), to
+	// keep lines from interleaving; formatting happens on a per-call
+	// buffer pulled from bufPool and needs no lock.
+	writeMu sync.Mutex
+	bufPool sync.Pool
+
+	// meta guards handlerOpts/attrs/group/formatter/fileFormatter/sinks/
+	// reporters, state that's only written by the With*/AddSink/
+	// AddErrorReporter family; log calls only take the read lock.
+	meta          sync.RWMutex
+	handlerOpts   slog.HandlerOptions
+	attrs         []slog.Attr
+	group         string
+	formatter     Formatter
+	fileFormatter Formatter
+	sinks         []Sink
+	reporters     []reporterEntry
 }
 
 // TODO: Singleton methods
@@ -37,6 +65,7 @@ type Prefix struct {
 	Color     []byte
 	File      bool
 	Callstack bool
+	Level     slog.Level
 }
 
 var (
@@ -51,260 +80,324 @@ var (
 		Plain: plainFatal,
 		Color: Red(plainFatal),
 		File:  true,
+		Level: LevelFatal,
 	}
 	ErrorPrefix = Prefix{
 		Plain: plainError,
 		Color: Red(plainError),
 		File:  true,
+		Level: slog.LevelError,
 	}
 	WarnPrefix = Prefix{
 		Plain: plainWarn,
 		Color: Orange(plainWarn),
+		Level: slog.LevelWarn,
 	}
 	InfoPrefix = Prefix{
 		Plain: plainInfo,
 		Color: Green(plainInfo),
+		Level: slog.LevelInfo,
 	}
 	DebugPrefix = Prefix{
 		Plain: plainDebug,
 		Color: Purple(plainDebug),
 		File:  true,
+		Level: slog.LevelDebug,
 	}
 	TracePrefix = Prefix{
 		Plain:     plainTrace,
 		Color:     Cyan(plainTrace),
 		Callstack: true,
+		Level:     LevelTrace,
 	}
 )
 
 // New returns new Logger instance with predefined writer output and
 // automatically detect terminal coloring support
 func New(out FdWriter) *Logger {
-	return &Logger{
-		color:     term.IsTerminal(int(out.Fd())),
-		out:       out,
-		timestamp: true,
+	l := &Logger{out: out}
+	l.color.Store(term.IsTerminal(int(out.Fd())))
+	l.timestamp.Store(true)
+	return l
+}
+
+// getBuffer returns a ColorBuffer from the pool, allocating a new one if
+// the pool is empty.
+func (l *Logger) getBuffer() *ColorBuffer {
+	if b, ok := l.bufPool.Get().(*ColorBuffer); ok {
+		return b
+	}
+	return new(ColorBuffer)
+}
+
+// putBuffer resets b and returns it to the pool, unless it has grown
+// past maxPooledBufferSize, in which case it's dropped so one large line
+// doesn't inflate every future allocation from the pool.
+func (l *Logger) putBuffer(b *ColorBuffer) {
+	if cap(b.Buffer) > maxPooledBufferSize {
+		return
 	}
+	b.Reset()
+	l.bufPool.Put(b)
+}
+
+// WithHandlerOptions configures the slog.HandlerOptions used when l is
+// consulted as a slog.Handler, e.g. to set a custom leveler via
+// opts.Level instead of relying on WithDebug/WithoutDebug.
+func (l *Logger) WithHandlerOptions(opts slog.HandlerOptions) *Logger {
+	l.meta.Lock()
+	defer l.meta.Unlock()
+	l.handlerOpts = opts
+	return l
 }
 
 // WithColor explicitly turns on colorful features on the logger
 func (l *Logger) WithColor() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.color = true
+	l.color.Store(true)
 	return l
 }
 
 // Sets the depth in reflection for debug logs
 func (l *Logger) Depth(depth int) *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.depth = depth
+	l.depth.Store(int32(depth))
 	return l
 }
 
 func (l *Logger) IsColored() bool {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return l.color
+	return l.color.Load()
 }
 
 // WithoutColor explicitly turns off colorful features on the log
 func (l *Logger) WithoutColor() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.color = false
+	l.color.Store(false)
 	return l
 }
 
-// WithLogFile turns on log saving to log file
+// WithLogFile turns on log saving to path, with no rotation policy
+// (the file is simply appended to forever). For rotation, retention or
+// compression, build a FileSink with NewFileSink and attach it with
+// AddSink instead; Close flushes and closes it deterministically rather
+// than relying on a finalizer.
 func (l *Logger) WithLogFile(path string) *Logger {
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	sink, err := NewFileSink(path, FileSinkOptions{})
 	if err != nil {
 		l.Error("could not open log file", path, err)
 		return l
 	}
-	runtime.SetFinalizer(l, func(l *Logger) {
-		l.Info("closing log file")
-		l.logFile.Close()
-	})
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.logFile = f
-	return l
+	return l.AddSink(sink)
 }
 
-// WithDebug turns on debugging output on the log to reveal debug and trace level
+// WithDebug turns on debugging output on the log to reveal debug level
+// messages; Trace messages stay hidden (they need LevelTrace).
+//
+// Deprecated: use WithLevel(LevelDebug) (or LevelTrace, to also show
+// Trace) for finer-grained control over which severities are shown.
 func (l *Logger) WithDebug() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.debug = true
-	return l
+	return l.WithLevel(LevelDebug)
 }
 
 func (l *Logger) WithoutDebug() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.debug = false
-	return l
+	return l.WithLevel(LevelInfo)
 }
 
 func (l *Logger) IsDebug() bool {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return l.debug
+	return l.Level() <= LevelDebug
 }
 
 // WithTimestamp turns on timestamp output on the log
 func (l *Logger) WithTimestamp() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.timestamp = true
+	l.timestamp.Store(true)
 	return l
 }
 
 func (l *Logger) WithoutTimestamp() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.timestamp = false
+	l.timestamp.Store(false)
 	return l
 }
 
 // Quiet turns off all log output
 func (l *Logger) Quiet() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.quiet = true
+	l.quiet.Store(true)
 	return l
 }
 
 func (l *Logger) NoQuiet() *Logger {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.quiet = false
+	l.quiet.Store(false)
 	return l
 }
 
 func (l *Logger) IsQuiet() bool {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return l.quiet
+	return l.quiet.Load()
 }
 
+// Output builds an Entry from prefix/data and renders it through the
+// configured Formatter(s), writing to l.out and, if attached, the log
+// file via its own formatter (see WithFileFormatter).
 func (l *Logger) Output(prefix Prefix, data string) error {
-	if l.logFile != nil {
-		loggerCopy := *l
-		// must use a new mutex to avoid dead locks or concurrent writes to l.mu
-		loggerCopy.mu = sync.RWMutex{}
-		loggerCopy.color = false
-		loggerCopy.out = loggerCopy.logFile
-		if err := loggerCopy.output(prefix, data); err != nil {
-			return err
+	return l.dispatch(l.buildEntry(prefix, data))
+}
+
+// buildEntry captures the current time, caller location (if prefix.File)
+// and call stack (if prefix.Callstack) into an Entry, along with any
+// attrs attached via With/WithAttrs.
+func (l *Logger) buildEntry(prefix Prefix, data string) Entry {
+	entry := Entry{
+		Level:         prefix.Level,
+		Prefix:        prefix,
+		Time:          time.Now(),
+		Message:       data,
+		ShowTimestamp: l.timestamp.Load(),
+	}
+	if prefix.File {
+		entry.File, entry.Function, entry.Line, _ = l.getOccurrence(0)
+	}
+	if prefix.Callstack {
+		entry.Stack = l.collectStack()
+	}
+	l.meta.RLock()
+	if len(l.attrs) > 0 {
+		entry.Attrs = append([]slog.Attr(nil), l.attrs...)
+	}
+	l.meta.RUnlock()
+	return entry
+}
+
+// collectStack walks up to 50 frames of the call stack, starting at
+// collectStack's own caller (shifted by Depth).
+func (l *Logger) collectStack() []StackFrame {
+	var frames []StackFrame
+	maxCallDepth := 50
+	for i := range maxCallDepth {
+		file, fn, line, ok := l.getOccurrence(i)
+		if !ok {
+			break
 		}
+		frames = append(frames, StackFrame{File: file, Function: fn, Line: line})
 	}
-	return l.output(prefix, data)
+	return frames
 }
 
-func (l *Logger) output(prefix Prefix, data string) error {
+// dispatch renders entry through l.out and every sink attached via
+// AddSink/WithLogFile, and forwards it to any matching ErrorReporter.
+// Sinks always get an uncolored copy, fanned out with a single format
+// pass; l.out gets entry rendered with l's current color setting.
+func (l *Logger) dispatch(entry Entry) error {
 	if l.IsQuiet() {
 		return nil
 	}
-	now := time.Now()
-	// Acquire exclusive access to the shared buffer
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	// Reset buffer so it start from the begining
-	l.buf.Reset()
-	// Write prefix to the buffer
-	if l.color {
-		l.buf.Append(prefix.Color)
-	} else {
-		l.buf.Append(prefix.Plain)
+	l.reportToReporters(entry)
+	l.meta.RLock()
+	sinks := l.sinks
+	l.meta.RUnlock()
+	var sinkErr error
+	if len(sinks) > 0 {
+		sinkEntry := entry
+		sinkEntry.Colored = false
+		sinkErr = l.writeToSinks(sinks, l.resolveFileFormatter(), &sinkEntry)
 	}
-	if l.timestamp {
-		if l.color {
-			l.buf.Blue()
-		}
-		year, month, day := now.Date()
-		l.buf.AppendInt(year, 4)
-		l.buf.AppendByte('/')
-		l.buf.AppendInt(int(month), 2)
-		l.buf.AppendByte('/')
-		l.buf.AppendInt(day, 2)
-		l.buf.AppendByte(' ')
-		hour, min, sec := now.Clock()
-		l.buf.AppendInt(hour, 2)
-		l.buf.AppendByte(':')
-		l.buf.AppendInt(min, 2)
-		l.buf.AppendByte(':')
-		l.buf.AppendInt(sec, 2)
-		l.buf.AppendByte(' ')
-		// Print reset color if color enabled
-		if l.color {
-			l.buf.Off()
-		}
+	entry.Colored = l.IsColored()
+	if err := l.write(l.out, l.resolveFormatter(), &entry); err != nil {
+		return err
 	}
-	// Add caller filename and line if enabled
-	if prefix.File {
-		file, fn, line, _ := l.getOccurrence(0)
-		if l.color {
-			l.buf.Orange()
-		}
-		// Print filename and line
-		l.buf.Append([]byte(fn))
-		l.buf.AppendByte(':')
-		l.buf.Append([]byte(file))
-		l.buf.AppendByte(':')
-		l.buf.AppendInt(line, 0)
-		l.buf.AppendByte(' ')
-		// Print color stop
-		if l.color {
-			l.buf.Off()
+	return sinkErr
+}
+
+// writeToSinks formats entry once and writes the result to every sink
+// independently, rather than through an io.MultiWriter: MultiWriter.Write
+// returns on a sink's first error instead of writing to the writers
+// after it, so one failing sink (a full disk, a down syslog connection)
+// would silently blackhole every sink registered after it. Every failed
+// sink write is logged locally once the fan-out is done (so a persistently
+// failing sink doesn't go unnoticed); the first error is also returned, for callers
+// that check dispatch/Output's return value. The whole fan-out happens
+// under a single writeMu acquisition, same as l.write, so two
+// concurrent log calls can't interleave their lines across sinks.
+func (l *Logger) writeToSinks(sinks []Sink, f Formatter, entry *Entry) error {
+	buf := l.getBuffer()
+	defer l.putBuffer(buf)
+	if err := f.Format(entry, buf); err != nil {
+		l.logSinkFailureLocally(err)
+		return err
+	}
+	var failures []error
+	l.writeMu.Lock()
+	for _, s := range sinks {
+		if _, err := s.Write(buf.Buffer); err != nil {
+			failures = append(failures, err)
 		}
 	}
-	// Print the actual string data from caller
-	l.buf.Append([]byte(data))
-	if len(data) == 0 || data[len(data)-1] != '\n' {
-		l.buf.AppendByte('\n')
+	l.writeMu.Unlock()
+	// logSinkFailureLocally writes to l.out itself and takes writeMu, so
+	// it must run after the fan-out above releases it.
+	for _, err := range failures {
+		l.logSinkFailureLocally(err)
 	}
-	// add call stack trace if enabled
-	if prefix.Callstack {
-		var ok bool
-		maxCallDepth := 50
-		for i := range maxCallDepth {
-			var file, fn string
-			var line int
-			file, fn, line, ok = l.getOccurrence(i)
-			if !ok {
-				break
-			}
-			if l.color {
-				l.buf.Gray()
-			}
-			// Print filename and line
-			l.buf.AppendByte('\t')
-			l.buf.Append([]byte(fn))
-			l.buf.AppendByte(':')
-			l.buf.Append([]byte(file))
-			l.buf.AppendByte(':')
-			l.buf.AppendInt(line, 0)
-			l.buf.AppendByte('\n')
-			// Print color stop
-			if l.color {
-				l.buf.Off()
-			}
-		}
+	if len(failures) == 0 {
+		return nil
 	}
-	// Flush buffer to output
-	_, err := l.out.Write(l.buf.Buffer)
+	return failures[0]
+}
+
+// write formats entry with f into a pooled buffer and writes the result
+// to w, serialized by writeMu so concurrent lines don't interleave.
+func (l *Logger) write(w io.Writer, f Formatter, entry *Entry) error {
+	buf := l.getBuffer()
+	defer l.putBuffer(buf)
+	if err := f.Format(entry, buf); err != nil {
+		return err
+	}
+	l.writeMu.Lock()
+	_, err := w.Write(buf.Buffer)
+	l.writeMu.Unlock()
 	return err
 }
 
+// WithFormatter sets the Formatter used to render entries written to
+// l.out. The default is TextFormatter, the colored renderer the package
+// has always used.
+func (l *Logger) WithFormatter(f Formatter) *Logger {
+	l.meta.Lock()
+	l.formatter = f
+	l.meta.Unlock()
+	return l
+}
+
+// WithFileFormatter sets the Formatter used for the attached log file,
+// independent of WithFormatter, so e.g. JSON can be written to disk
+// while the terminal keeps the colored text format. If unset, the log
+// file uses the same formatter as l.out.
+func (l *Logger) WithFileFormatter(f Formatter) *Logger {
+	l.meta.Lock()
+	l.fileFormatter = f
+	l.meta.Unlock()
+	return l
+}
+
+func (l *Logger) resolveFormatter() Formatter {
+	l.meta.RLock()
+	f := l.formatter
+	l.meta.RUnlock()
+	if f == nil {
+		return TextFormatter{}
+	}
+	return f
+}
+
+func (l *Logger) resolveFileFormatter() Formatter {
+	l.meta.RLock()
+	f := l.fileFormatter
+	l.meta.RUnlock()
+	if f != nil {
+		return f
+	}
+	return l.resolveFormatter()
+}
+
 func (l *Logger) getOccurrence(additionalDepth int) (file, fn string, line int, ok bool) {
 	var pc uintptr
 
 	// Get the caller filename and line
-	if pc, file, line, ok = runtime.Caller(l.depth + 2 + additionalDepth); !ok {
+	if pc, file, line, ok = runtime.Caller(int(l.depth.Load()) + 2 + additionalDepth); !ok {
 		file = "<unknown file>"
 		fn = "<unknown function>"
 		line = 0
@@ -317,64 +410,82 @@ func (l *Logger) getOccurrence(additionalDepth int) (file, fn string, line int,
 
 // Fatal print fatal message to output and quit the application with status 1
 func (l *Logger) Fatal(v ...interface{}) {
-	l.Output(FatalPrefix, fmt.Sprintln(v...))
+	if l.enabled(FatalPrefix.Level) {
+		l.Output(FatalPrefix, fmt.Sprintln(v...))
+	}
+	l.flushSinks()
 	os.Exit(1)
 }
 
 // Fatalf print formatted fatal message to output and quit the application
 // with status 1
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.Output(FatalPrefix, fmt.Sprintf(format, v...))
+	if l.enabled(FatalPrefix.Level) {
+		l.Output(FatalPrefix, fmt.Sprintf(format, v...))
+	}
+	l.flushSinks()
 	os.Exit(1)
 }
 
-// Error print error message to output
+// Error print error message to output, if l's Level allows it
 func (l *Logger) Error(v ...interface{}) {
-	l.Output(ErrorPrefix, fmt.Sprintln(v...))
+	if l.enabled(ErrorPrefix.Level) {
+		l.Output(ErrorPrefix, fmt.Sprintln(v...))
+	}
 }
 
-// Errorf print formatted error message to output
+// Errorf print formatted error message to output, if l's Level allows it
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.Output(ErrorPrefix, fmt.Sprintf(format, v...))
+	if l.enabled(ErrorPrefix.Level) {
+		l.Output(ErrorPrefix, fmt.Sprintf(format, v...))
+	}
 }
 
-// Warn print warning message to output
+// Warn print warning message to output, if l's Level allows it
 func (l *Logger) Warn(v ...interface{}) {
-	l.Output(WarnPrefix, fmt.Sprintln(v...))
+	if l.enabled(WarnPrefix.Level) {
+		l.Output(WarnPrefix, fmt.Sprintln(v...))
+	}
 }
 
-// Warnf print formatted warning message to output
+// Warnf print formatted warning message to output, if l's Level allows it
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	l.Output(WarnPrefix, fmt.Sprintf(format, v...))
+	if l.enabled(WarnPrefix.Level) {
+		l.Output(WarnPrefix, fmt.Sprintf(format, v...))
+	}
 }
 
-// Info print informational message to output
+// Info print informational message to output, if l's Level allows it
 func (l *Logger) Info(v ...interface{}) {
-	l.Output(InfoPrefix, fmt.Sprintln(v...))
+	if l.enabled(InfoPrefix.Level) {
+		l.Output(InfoPrefix, fmt.Sprintln(v...))
+	}
 }
 
-// Infof print formatted informational message to output
+// Infof print formatted informational message to output, if l's Level allows it
 func (l *Logger) Infof(format string, v ...interface{}) {
-	l.Output(InfoPrefix, fmt.Sprintf(format, v...))
+	if l.enabled(InfoPrefix.Level) {
+		l.Output(InfoPrefix, fmt.Sprintf(format, v...))
+	}
 }
 
-// Debug print debug message to output if debug output enabled
+// Debug print debug message to output, if l's Level allows it
 func (l *Logger) Debug(v ...interface{}) {
-	if l.IsDebug() {
+	if l.enabled(DebugPrefix.Level) {
 		l.Output(DebugPrefix, fmt.Sprintln(v...))
 	}
 }
 
-// Debugf print formatted debug message to output if debug output enabled
+// Debugf print formatted debug message to output, if l's Level allows it
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l.IsDebug() {
+	if l.enabled(DebugPrefix.Level) {
 		l.Output(DebugPrefix, fmt.Sprintf(format, v...))
 	}
 }
 
-// Trace print trace message to output if debug output enabled
+// Trace print trace message to output, if l's Level allows it
 func (l *Logger) Trace(v ...interface{}) {
-	if l.IsDebug() {
+	if l.enabled(TracePrefix.Level) {
 		l.Output(TracePrefix, fmt.Sprintln(v...))
 	}
 }
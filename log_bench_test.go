@@ -0,0 +1,27 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"io"
+	"testing"
+)
+
+// BenchmarkInfoConcurrent measures throughput of concurrent Info calls,
+// which exercises the sync.Pool'd per-call buffers and the write mutex
+// that replaced the old full-output lock.
+func BenchmarkInfoConcurrent(b *testing.B) {
+	logger := log.New(devNull{}).WithoutColor().WithoutTimestamp()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.Info("benchmark message")
+		}
+	})
+}
+
+type devNull struct{}
+
+func (devNull) Write(p []byte) (int, error) { return len(p), nil }
+func (devNull) Fd() uintptr                 { return uintptr(0) }
+
+var _ io.Writer = devNull{}
@@ -3,7 +3,6 @@ package colored_logging_test
 import (
 	log "colored_logging"
 	"os"
-	"runtime"
 	"testing"
 )
 
@@ -22,15 +21,18 @@ func TestNoDebug(t *testing.T) {
 	logger.Debug("debug")
 }
 
-func inner_func() {
+func inner_func(t *testing.T) {
 	logger := log.New(os.Stdout).WithDebug().WithLogFile("test.log")
 	logger.Info("info")
 	logger.Warn("warning")
 	logger.Error("error")
 	logger.Debug("debug")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
 }
 
 func TestLogFile(t *testing.T) {
-	inner_func()
-	runtime.GC() // log file closes automatically
+	inner_func(t)
+	t.Cleanup(func() { os.Remove("test.log") })
 }
@@ -0,0 +1,45 @@
+package colored_logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LogfmtFormatter renders an Entry as space-separated key=value pairs,
+// the format used by tools like Heroku's logplex and many Go services.
+type LogfmtFormatter struct{}
+
+// Format implements Formatter.
+func (LogfmtFormatter) Format(entry *Entry, buf *ColorBuffer) error {
+	writePair(buf, "time", entry.Time.Format(timeFormat))
+	buf.AppendByte(' ')
+	writePair(buf, "level", levelName(entry.Level))
+	if entry.Prefix.File {
+		buf.AppendByte(' ')
+		writePair(buf, "func", entry.Function)
+		buf.AppendByte(' ')
+		writePair(buf, "file", entry.File)
+		buf.AppendByte(' ')
+		writePair(buf, "line", strconv.Itoa(entry.Line))
+	}
+	buf.AppendByte(' ')
+	writePair(buf, "msg", trimTrailingNewline(entry.Message))
+	for _, a := range entry.Attrs {
+		buf.AppendByte(' ')
+		writePair(buf, a.Key, a.Value.String())
+	}
+	buf.AppendByte('\n')
+	return nil
+}
+
+// writePair appends key=value to buf, quoting value with %q if it
+// contains whitespace or an '=' that would otherwise break parsing.
+func writePair(buf *ColorBuffer, key, value string) {
+	buf.Append([]byte(key))
+	buf.AppendByte('=')
+	if strings.ContainsAny(value, " \t\r\n\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	buf.Append([]byte(value))
+}
@@ -0,0 +1,140 @@
+package colored_logging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ErrorReporter forwards log entries to an external observability
+// service (Sentry, OpsGenie and the like). Report must not retain or
+// mutate entry: the same *Entry may be handed to several reporters
+// concurrently.
+type ErrorReporter interface {
+	Report(ctx context.Context, entry *Entry) error
+}
+
+// reporterEntry pairs a registered ErrorReporter with the minimum Level
+// it wants to see.
+type reporterEntry struct {
+	reporter ErrorReporter
+	minLevel Level
+}
+
+// reporterJob is one queued ErrorReporter invocation. logger is the
+// Logger that enqueued it, used only to log a local failure, since the
+// worker pool itself is shared process-wide rather than owned by any
+// one Logger.
+type reporterJob struct {
+	logger   *Logger
+	reporter ErrorReporter
+	entry    *Entry
+}
+
+// reporterWorkerCount is the size of the fixed worker pool every
+// registered ErrorReporter shares; Report calls never run on the
+// logging goroutine itself.
+const reporterWorkerCount = 4
+
+// reporterQueueCapacity bounds how many reporter invocations can be
+// buffered before the logging call starts dropping them (see
+// reportToReporters), keeping dispatch non-blocking even if a reporter
+// is slow.
+const reporterQueueCapacity = 256
+
+// reportQueue and reportOnce back a single process-wide worker pool
+// shared by every Logger that registers an ErrorReporter. The pool is
+// package-level rather than per-Logger so that With/WithGroup clones
+// (which share reporters with the Logger they were cloned from) don't
+// each spin up their own queue and goroutines the first time they
+// happen to call AddErrorReporter themselves.
+var (
+	reportQueue chan reporterJob
+	reportOnce  sync.Once
+)
+
+// AddErrorReporter registers r to receive every entry at or above
+// minLevel, in addition to it being printed locally as usual. Report is
+// invoked on a small background worker pool shared by the whole
+// process, never inline with the Error/Fatal/... call that produced the
+// entry.
+func (l *Logger) AddErrorReporter(r ErrorReporter, minLevel Level) *Logger {
+	startReportWorkers()
+	l.meta.Lock()
+	l.reporters = append(append([]reporterEntry(nil), l.reporters...), reporterEntry{
+		reporter: r,
+		minLevel: minLevel,
+	})
+	l.meta.Unlock()
+	return l
+}
+
+// startReportWorkers lazily spins up the shared worker pool the first
+// time any Logger registers a reporter, so a process that never calls
+// AddErrorReporter never pays for the goroutines or the queue.
+func startReportWorkers() {
+	reportOnce.Do(func() {
+		reportQueue = make(chan reporterJob, reporterQueueCapacity)
+		for i := 0; i < reporterWorkerCount; i++ {
+			go reportWorker()
+		}
+	})
+}
+
+// reportWorker drains reportQueue for the lifetime of the process.
+// Errors from Report are logged locally on the Logger that enqueued the
+// job, rather than recursing back through dispatch (which would risk
+// feeding a persistently failing reporter right back into the report
+// queue).
+func reportWorker() {
+	for job := range reportQueue {
+		if err := job.reporter.Report(context.Background(), job.entry); err != nil {
+			job.logger.logReporterFailureLocally(err)
+		}
+	}
+}
+
+// reportToReporters enqueues entry for every registered reporter whose
+// minLevel it meets. It never blocks: a full queue drops the job (and
+// logs that locally) instead of slowing down the caller. Stack is
+// populated on demand, the same way TracePrefix entries get theirs,
+// since reporters need it even for severities that don't normally
+// collect a call stack.
+func (l *Logger) reportToReporters(entry Entry) {
+	l.meta.RLock()
+	reporters := l.reporters
+	l.meta.RUnlock()
+	if len(reporters) == 0 {
+		return
+	}
+
+	var matched []ErrorReporter
+	for _, re := range reporters {
+		if entry.Level >= re.minLevel {
+			matched = append(matched, re.reporter)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+	if len(entry.Stack) == 0 {
+		entry.Stack = l.collectStack()
+	}
+
+	for _, r := range matched {
+		select {
+		case reportQueue <- reporterJob{logger: l, reporter: r, entry: &entry}:
+		default:
+			l.logReporterFailureLocally(fmt.Errorf("report queue full, dropped %s entry", entry.Message))
+		}
+	}
+}
+
+// logReporterFailureLocally writes directly to l.out, bypassing
+// dispatch/reportToReporters entirely so a failing or overloaded
+// reporter can never trigger infinite recursion through itself.
+func (l *Logger) logReporterFailureLocally(err error) {
+	entry := l.buildEntry(ErrorPrefix, fmt.Sprintf("error reporter failed: %v\n", err))
+	entry.Colored = l.IsColored()
+	l.write(l.out, l.resolveFormatter(), &entry)
+}
@@ -0,0 +1,91 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingReporter struct {
+	mu      sync.Mutex
+	entries []*log.Entry
+}
+
+func (r *recordingReporter) Report(_ context.Context, entry *log.Entry) error {
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *recordingReporter) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+func waitUntil(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAddErrorReporterForwardsAboveMinLevel(t *testing.T) {
+	reporter := &recordingReporter{}
+	logger := log.New(devNull{}).WithoutColor().AddErrorReporter(reporter, slog.LevelError)
+
+	logger.Warn("should not be reported")
+	logger.Error("should be reported")
+
+	waitUntil(t, time.Second, func() bool { return reporter.count() == 1 })
+	if got := reporter.entries[0].Message; got != "should be reported\n" {
+		t.Fatalf("unexpected entry forwarded: %q", got)
+	}
+	if len(reporter.entries[0].Stack) == 0 {
+		t.Fatal("expected reported entry to carry a call stack")
+	}
+}
+
+func TestAddErrorReporterIncludesAttrs(t *testing.T) {
+	reporter := &recordingReporter{}
+	logger := log.New(devNull{}).WithoutColor().
+		AddErrorReporter(reporter, slog.LevelError).
+		With("req_id", "abc123")
+
+	logger.Error("boom")
+	waitUntil(t, time.Second, func() bool { return reporter.count() == 1 })
+
+	entry := reporter.entries[0]
+	if len(entry.Attrs) != 1 || entry.Attrs[0].Key != "req_id" {
+		t.Fatalf("expected req_id attr on reported entry, got %+v", entry.Attrs)
+	}
+}
+
+type failingReporter struct{ calls atomic.Int64 }
+
+func (f *failingReporter) Report(context.Context, *log.Entry) error {
+	f.calls.Add(1)
+	return errors.New("reporter unavailable")
+}
+
+func TestFailingReporterDoesNotRecurse(t *testing.T) {
+	reporter := &failingReporter{}
+	logger := log.New(devNull{}).WithoutColor().AddErrorReporter(reporter, slog.LevelError)
+
+	logger.Error("first failure")
+	time.Sleep(50 * time.Millisecond)
+
+	if got := reporter.calls.Load(); got != 1 {
+		t.Fatalf("expected exactly 1 reporter invocation, got %d", got)
+	}
+}
@@ -0,0 +1,86 @@
+package colored_logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Sink is an additional log destination beyond l.out, fanned out to
+// from dispatch with l.out's timestamp setting but always uncolored.
+// FileSink is the built-in implementation; a network syslog
+// writer or anything else satisfying io.WriteCloser works just as well.
+type Sink interface {
+	io.Writer
+	io.Closer
+}
+
+// Flusher is implemented by sinks that buffer records asynchronously
+// (see AsyncSink) and so need a chance to drain on demand rather than
+// only on Close. Logger.Fatal/Fatalf flush every such sink before
+// exiting, so the message that triggered the exit isn't lost in the
+// buffer.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// fatalFlushTimeout bounds how long Fatal/Fatalf wait for Flusher sinks
+// to drain before exiting, so a stuck sink can't hang process shutdown.
+const fatalFlushTimeout = 5 * time.Second
+
+// flushSinks gives every attached Flusher sink a bounded chance to drain
+// before the process exits.
+func (l *Logger) flushSinks() {
+	l.meta.RLock()
+	sinks := l.sinks
+	l.meta.RUnlock()
+	if len(sinks) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fatalFlushTimeout)
+	defer cancel()
+	for _, s := range sinks {
+		if f, ok := s.(Flusher); ok {
+			f.Flush(ctx)
+		}
+	}
+}
+
+// logSinkFailureLocally writes directly to l.out, bypassing the sink
+// fan-out entirely so a failing sink is reported without routing back
+// through the sink that caused the failure.
+func (l *Logger) logSinkFailureLocally(err error) {
+	entry := l.buildEntry(ErrorPrefix, fmt.Sprintf("sink write failed: %v\n", err))
+	entry.Colored = l.IsColored()
+	l.write(l.out, l.resolveFormatter(), &entry)
+}
+
+// AddSink attaches sink so every subsequent log line is also written to
+// it (uncolored, via the file formatter set by WithFileFormatter if
+// any). Multiple sinks can be attached to fan out to e.g. a rotating
+// file and a syslog writer simultaneously.
+func (l *Logger) AddSink(sink Sink) *Logger {
+	l.meta.Lock()
+	l.sinks = append(append([]Sink(nil), l.sinks...), sink)
+	l.meta.Unlock()
+	return l
+}
+
+// Close flushes and closes every sink attached via AddSink or
+// WithLogFile, replacing the runtime-finalizer-based cleanup earlier
+// versions relied on (finalizers may never run, and the copy-of-Logger
+// dance in With/WithGroup breaks finalizer semantics anyway). It closes
+// every sink even if one fails, returning the first error encountered.
+func (l *Logger) Close() error {
+	l.meta.RLock()
+	sinks := l.sinks
+	l.meta.RUnlock()
+	var firstErr error
+	for _, s := range sinks {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
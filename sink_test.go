@@ -0,0 +1,101 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFileSinkRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := log.NewFileSink(path, log.FileSinkOptions{MaxSizeBytes: 10, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := sink.Write([]byte("0123456789\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Fatal("expected at least one rotated segment")
+	}
+	if len(matches) > 1 {
+		t.Fatalf("expected MaxBackups=1 to prune old segments, found %d", len(matches))
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected active log file to still exist: %v", err)
+	}
+}
+
+func TestAddSinkFansOutAndClose(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fanout.log")
+
+	logger := log.New(os.Stdout).WithoutColor()
+	sink, err := log.NewFileSink(path, log.FileSinkOptions{})
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	logger.AddSink(sink)
+	logger.Info("fanned out")
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(data) == 0 {
+		t.Fatal("expected sink to receive the log line")
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) Write([]byte) (int, error) { return 0, errors.New("sink unavailable") }
+func (failingSink) Close() error              { return nil }
+
+type recordingSink struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (s *recordingSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	s.data = append(s.data, p...)
+	s.mu.Unlock()
+	return len(p), nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+func TestAddSinkWritesIndependentlyOfEarlierFailures(t *testing.T) {
+	recording := &recordingSink{}
+	logger := log.New(devNull{}).WithoutColor().AddSink(failingSink{}).AddSink(recording)
+
+	logger.Info("line one")
+
+	recording.mu.Lock()
+	got := string(recording.data)
+	recording.mu.Unlock()
+	if !strings.Contains(got, "line one") {
+		t.Fatalf("expected recording sink to receive the line despite the earlier sink failing, got %q", got)
+	}
+}
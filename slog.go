@@ -0,0 +1,177 @@
+package colored_logging
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+)
+
+// Handler returns l as a slog.Handler. Logger implements the interface
+// directly, so no adapter type is needed.
+func (l *Logger) Handler() slog.Handler {
+	return l
+}
+
+// Slog returns a *slog.Logger backed by l, letting callers use the
+// standard library API while still getting colored, prefixed output.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(l)
+}
+
+// Enabled reports whether l would emit a record at level. When
+// HandlerOptions.Level is set it takes priority; otherwise Enabled falls
+// back to l's own Level threshold, same as Info/Debug/... in log.go.
+func (l *Logger) Enabled(_ context.Context, level slog.Level) bool {
+	l.meta.RLock()
+	leveler := l.handlerOpts.Level
+	l.meta.RUnlock()
+	if leveler != nil {
+		if l.IsQuiet() {
+			return false
+		}
+		return level >= leveler.Level()
+	}
+	return l.enabled(level)
+}
+
+// Handle renders a slog.Record through the same Entry/Formatter
+// pipeline Output uses, so structured attributes are rendered exactly
+// like the Logger's own With-attached attrs.
+func (l *Logger) Handle(_ context.Context, r slog.Record) error {
+	prefix := prefixForLevel(r.Level)
+	entry := l.buildEntry(prefix, r.Message)
+	// r.PC is the actual call site regardless of how deep slog's plumbing
+	// is, so it's more reliable than the depth-based getOccurrence used
+	// for the plain Info/Error/... API.
+	if r.PC != 0 {
+		if frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next(); frame.PC != 0 {
+			entry.File = filepath.Base(frame.File)
+			entry.Function = frame.Function
+			entry.Line = frame.Line
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		entry.Attrs = append(entry.Attrs, slog.Attr{Key: l.qualify(a.Key), Value: a.Value})
+		return true
+	})
+	return l.dispatch(entry)
+}
+
+// WithAttrs returns a copy of l with attrs appended immutably; the
+// receiver is left untouched so logger.With(...) is safe to share.
+func (l *Logger) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return l
+	}
+	c := l.clone()
+	for _, a := range attrs {
+		c.attrs = append(c.attrs, slog.Attr{Key: c.qualify(a.Key), Value: a.Value})
+	}
+	return c
+}
+
+// WithGroup returns a copy of l that namespaces subsequently added
+// attributes under name, mirroring slog.Logger.WithGroup.
+func (l *Logger) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return l
+	}
+	c := l.clone()
+	if c.group != "" {
+		c.group = c.group + "." + name
+	} else {
+		c.group = name
+	}
+	return c
+}
+
+// With attaches key/value pairs (or slog.Attr values) to a copy of l,
+// following the same argument conventions as slog.Logger.With.
+func (l *Logger) With(args ...interface{}) *Logger {
+	if len(args) == 0 {
+		return l
+	}
+	h := l.WithAttrs(argsToAttrs(args))
+	return h.(*Logger)
+}
+
+// clone makes a copy of l safe to mutate independently: it shares l's
+// output, sinks, reporters and settings but gets its own buffer pool,
+// write mutex and a fresh backing array for attrs.
+func (l *Logger) clone() *Logger {
+	c := &Logger{
+		out: l.out,
+	}
+	c.color.Store(l.color.Load())
+	c.level.Store(l.level.Load())
+	c.verbosity.Store(l.verbosity.Load())
+	c.timestamp.Store(l.timestamp.Load())
+	c.quiet.Store(l.quiet.Load())
+	c.depth.Store(l.depth.Load())
+	if table := l.vmodule.Load(); table != nil {
+		c.vmodule.Store(table)
+	}
+
+	l.meta.RLock()
+	c.handlerOpts = l.handlerOpts
+	c.group = l.group
+	c.attrs = append([]slog.Attr(nil), l.attrs...)
+	c.formatter = l.formatter
+	c.fileFormatter = l.fileFormatter
+	c.sinks = append([]Sink(nil), l.sinks...)
+	c.reporters = append([]reporterEntry(nil), l.reporters...)
+	l.meta.RUnlock()
+	return c
+}
+
+// qualify prefixes key with the logger's current group, if any.
+func (l *Logger) qualify(key string) string {
+	l.meta.RLock()
+	group := l.group
+	l.meta.RUnlock()
+	if group == "" {
+		return key
+	}
+	return group + "." + key
+}
+
+// prefixForLevel maps a slog.Level to the existing Prefix values, with
+// anything below slog.LevelDebug treated as Trace.
+func prefixForLevel(level slog.Level) Prefix {
+	switch {
+	case level >= slog.LevelError:
+		return ErrorPrefix
+	case level >= slog.LevelWarn:
+		return WarnPrefix
+	case level >= slog.LevelInfo:
+		return InfoPrefix
+	case level >= slog.LevelDebug:
+		return DebugPrefix
+	default:
+		return TracePrefix
+	}
+}
+
+// argsToAttrs converts a slog.Logger.With-style argument list into
+// slog.Attr values, tolerating trailing unpaired keys the same way slog
+// does (recorded under the "!BADKEY" key).
+func argsToAttrs(args []interface{}) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(args)/2+1)
+	for i := 0; i < len(args); i++ {
+		switch v := args[i].(type) {
+		case slog.Attr:
+			attrs = append(attrs, v)
+		case string:
+			if i+1 < len(args) {
+				attrs = append(attrs, slog.Attr{Key: v, Value: slog.AnyValue(args[i+1])})
+				i++
+			} else {
+				attrs = append(attrs, slog.Attr{Key: "!BADKEY", Value: slog.StringValue(v)})
+			}
+		default:
+			attrs = append(attrs, slog.Attr{Key: "!BADKEY", Value: slog.AnyValue(v)})
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,36 @@
+package colored_logging_test
+
+import (
+	log "colored_logging"
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	logger := log.New(os.Stdout).WithDebug()
+	slogger := logger.Slog()
+	slogger.Info("hello", "req_id", "abc123")
+	slogger.With("component", "router").Warn("slow request")
+}
+
+func TestWithAttrsDoesNotMutateParent(t *testing.T) {
+	logger := log.New(os.Stdout)
+	child := logger.With("req_id", "abc123")
+	if child == logger {
+		t.Fatal("With should return a distinct Logger")
+	}
+	child.Info("child has attrs")
+	logger.Info("parent stays unaffected")
+}
+
+func TestHandlerRespectsLevel(t *testing.T) {
+	logger := log.New(os.Stdout).WithHandlerOptions(slog.HandlerOptions{Level: slog.LevelWarn})
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Fatal("Info should be filtered out when handler level is Warn")
+	}
+	if !logger.Enabled(context.Background(), slog.LevelError) {
+		t.Fatal("Error should pass when handler level is Warn")
+	}
+}
@@ -0,0 +1,88 @@
+package colored_logging
+
+// TextFormatter renders an Entry the way Logger always has: an optional
+// colored prefix, timestamp, caller location and call stack, followed by
+// the message and any structured attributes as trailing key=value pairs.
+type TextFormatter struct{}
+
+// Format implements Formatter.
+func (TextFormatter) Format(entry *Entry, buf *ColorBuffer) error {
+	if entry.Colored {
+		buf.Append(entry.Prefix.Color)
+	} else {
+		buf.Append(entry.Prefix.Plain)
+	}
+	if entry.ShowTimestamp {
+		if entry.Colored {
+			buf.Blue()
+		}
+		year, month, day := entry.Time.Date()
+		buf.AppendInt(year, 4)
+		buf.AppendByte('/')
+		buf.AppendInt(int(month), 2)
+		buf.AppendByte('/')
+		buf.AppendInt(day, 2)
+		buf.AppendByte(' ')
+		hour, min, sec := entry.Time.Clock()
+		buf.AppendInt(hour, 2)
+		buf.AppendByte(':')
+		buf.AppendInt(min, 2)
+		buf.AppendByte(':')
+		buf.AppendInt(sec, 2)
+		buf.AppendByte(' ')
+		if entry.Colored {
+			buf.Off()
+		}
+	}
+	if entry.Prefix.File {
+		if entry.Colored {
+			buf.Orange()
+		}
+		buf.Append([]byte(entry.Function))
+		buf.AppendByte(':')
+		buf.Append([]byte(entry.File))
+		buf.AppendByte(':')
+		buf.AppendInt(entry.Line, 0)
+		buf.AppendByte(' ')
+		if entry.Colored {
+			buf.Off()
+		}
+	}
+	if len(entry.Attrs) == 0 {
+		buf.Append([]byte(entry.Message))
+		if len(entry.Message) == 0 || entry.Message[len(entry.Message)-1] != '\n' {
+			buf.AppendByte('\n')
+		}
+	} else {
+		buf.Append([]byte(trimTrailingNewline(entry.Message)))
+		for _, a := range entry.Attrs {
+			buf.AppendByte(' ')
+			if entry.Colored {
+				buf.Cyan()
+			}
+			buf.Append([]byte(a.Key))
+			if entry.Colored {
+				buf.Off()
+			}
+			buf.AppendByte('=')
+			buf.Append([]byte(a.Value.String()))
+		}
+		buf.AppendByte('\n')
+	}
+	for _, frame := range entry.Stack {
+		if entry.Colored {
+			buf.Gray()
+		}
+		buf.AppendByte('\t')
+		buf.Append([]byte(frame.Function))
+		buf.AppendByte(':')
+		buf.Append([]byte(frame.File))
+		buf.AppendByte(':')
+		buf.AppendInt(frame.Line, 0)
+		buf.AppendByte('\n')
+		if entry.Colored {
+			buf.Off()
+		}
+	}
+	return nil
+}